@@ -0,0 +1,23 @@
+// Package arena translates external tournament-bot protocols into the
+// module's internal game.GameState and back, so the AI code never has to
+// know which protocol a given opponent server speaks.
+package arena
+
+import "github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+
+// Codec unmarshals one tournament protocol's turn request into the
+// module's internal game state, and marshals a chosen move back into
+// that protocol's response shape. Additional protocols are added by
+// implementing a new Codec, without touching the AI.
+type Codec interface {
+	// Unmarshal parses data into a GameState plus the symbol this bot is
+	// asked to play as (mapped internally to game.AI_O), and whether
+	// this message is actually asking the bot to move now — some
+	// protocol messages (e.g. a new-game announcement) just inform the
+	// bot of state without it being the bot's turn.
+	Unmarshal(data []byte) (state *game.GameState, you string, yourTurn bool, err error)
+
+	// Marshal builds the response to data's request now that move (a
+	// flat board index, or -1 for no move) has been chosen.
+	Marshal(data []byte, boardSize, move int) ([]byte, error)
+}
@@ -0,0 +1,149 @@
+package arena
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+)
+
+// QuestionMessage is the BotsArena/Bolosseum-style envelope sent to the
+// bot for each turn.
+type QuestionMessage struct {
+	GameID      string            `json:"game-id"`
+	Action      string            `json:"action"`
+	Players     []string          `json:"players"`
+	You         string            `json:"you"`
+	PlayerIndex int               `json:"player-index"`
+	Board       map[string]string `json:"board"`
+}
+
+// Coordinate is a board cell in the arena's (row, column) terms.
+type Coordinate struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// ResponseMessage echoes the request's envelope back with the chosen
+// move attached.
+type ResponseMessage struct {
+	GameID      string      `json:"game-id"`
+	Action      string      `json:"action"`
+	Players     []string    `json:"players"`
+	You         string      `json:"you"`
+	PlayerIndex int         `json:"player-index"`
+	Move        *Coordinate `json:"move,omitempty"`
+}
+
+// QuestionCodec implements Codec for the BotsArena/Bolosseum-style
+// QuestionMessage envelope.
+type QuestionCodec struct{}
+
+// actionYourTurn is the QuestionMessage Action value the arena sends when
+// it is actually this bot's turn to move; other actions (e.g. a
+// new-game or game-over announcement) just inform the bot of state.
+const actionYourTurn = "your-turn"
+
+func (QuestionCodec) Unmarshal(data []byte) (*game.GameState, string, bool, error) {
+	var msg QuestionMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, "", false, err
+	}
+
+	board, n, err := decodeBoard(msg.Board, msg.You)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	return &game.GameState{Board: board, BoardSize: n}, msg.You, msg.Action == actionYourTurn, nil
+}
+
+func (QuestionCodec) Marshal(data []byte, boardSize, move int) ([]byte, error) {
+	var msg QuestionMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+
+	resp := ResponseMessage{
+		GameID:      msg.GameID,
+		Action:      msg.Action,
+		Players:     msg.Players,
+		You:         msg.You,
+		PlayerIndex: msg.PlayerIndex,
+	}
+	if move >= 0 && boardSize > 0 {
+		resp.Move = &Coordinate{X: move / boardSize, Y: move % boardSize}
+	}
+
+	return json.Marshal(resp)
+}
+
+// decodeBoard turns the arena's {"0-0": "X", ...} map into a flat,
+// row-major board sized by the largest "r-c" key, translating you's
+// symbol to game.AI_O and any other occupied cell to game.PLAYER_X so
+// the AI always plays as O regardless of what the arena calls it. Since
+// the arena is an external, untrusted tournament server, every key's
+// coordinates are bounds-checked before they're used to size or index
+// the board.
+func decodeBoard(board map[string]string, you string) ([]string, int, error) {
+	type cell struct {
+		r, c  int
+		value string
+	}
+
+	cells := make([]cell, 0, len(board))
+	n := 0
+	for key, value := range board {
+		r, c, err := parseCoordKey(key)
+		if err != nil {
+			return nil, 0, err
+		}
+		if r < 0 || c < 0 {
+			return nil, 0, fmt.Errorf("invalid board key %q: negative coordinate", key)
+		}
+		if r >= game.MaxBoardSize || c >= game.MaxBoardSize {
+			return nil, 0, fmt.Errorf("invalid board key %q: coordinate out of range", key)
+		}
+		cells = append(cells, cell{r: r, c: c, value: value})
+		if r+1 > n {
+			n = r + 1
+		}
+		if c+1 > n {
+			n = c + 1
+		}
+	}
+
+	flat := make([]string, n*n)
+	for i := range flat {
+		flat[i] = game.EMPTY
+	}
+	for _, cl := range cells {
+		switch {
+		case cl.value == "":
+			flat[cl.r*n+cl.c] = game.EMPTY
+		case cl.value == you:
+			flat[cl.r*n+cl.c] = game.AI_O
+		default:
+			flat[cl.r*n+cl.c] = game.PLAYER_X
+		}
+	}
+	return flat, n, nil
+}
+
+func parseCoordKey(key string) (int, int, error) {
+	parts := strings.SplitN(key, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid board key %q", key)
+	}
+	r, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid board key %q: %w", key, err)
+	}
+	c, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid board key %q: %w", key, err)
+	}
+	return r, c, nil
+}
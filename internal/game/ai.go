@@ -0,0 +1,128 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// ChooseAIMove routes to the correct AI logic based on board size and
+// returns the chosen index without applying it, so callers that need the
+// index itself (e.g. the arena adapter) don't have to diff the board.
+func ChooseAIMove(state *GameState) int {
+	if state.AIMode == AIModeLearned && state.Learned != nil {
+		if move := state.Learned.Move(state.Board, state.BoardSize, AI_O); move != -1 {
+			return move
+		}
+		// Table has no entry for this state yet; fall through to search
+		// so the AI still makes a legal move.
+	}
+
+	if state.BoardSize == 3 {
+		// Use "perfect" Minimax for 3x3
+		return FindBestMoveMinimax(state.Board)
+	}
+
+	// Use iterative-deepening alpha-beta for 4x4, 5x5, etc., where
+	// minimax is no longer feasible to run to completion.
+	think := time.Duration(state.MaxThinkMS) * time.Millisecond
+	if think <= 0 {
+		think = defaultThinkTime
+	}
+	return FindBestMoveAlphaBeta(state.Board, state.BoardSize, time.Now().Add(think))
+}
+
+// AIMove routes to the correct AI logic based on board size and applies
+// the chosen move to state.Board.
+func AIMove(state *GameState) {
+	bestMove := ChooseAIMove(state)
+	if bestMove != -1 && state.Board[bestMove] == EMPTY {
+		state.Board[bestMove] = AI_O
+	}
+}
+
+// --- AI: 3x3 Minimax (Unbeatable) Logic ---
+
+// (This win checker is simplified and hardcoded for 3x3 for the Minimax)
+func minimaxWinChecker(b []string, p string) bool {
+	return (b[0] == p && b[1] == p && b[2] == p) ||
+		(b[3] == p && b[4] == p && b[5] == p) ||
+		(b[6] == p && b[7] == p && b[8] == p) ||
+		(b[0] == p && b[3] == p && b[6] == p) ||
+		(b[1] == p && b[4] == p && b[7] == p) ||
+		(b[2] == p && b[5] == p && b[8] == p) ||
+		(b[0] == p && b[4] == p && b[8] == p) ||
+		(b[2] == p && b[4] == p && b[6] == p)
+}
+
+// FindBestMoveMinimax is the entry point for the 3x3 AI
+func FindBestMoveMinimax(board []string) int {
+	bestVal := -int(math.Inf(1))
+	bestMove := -1
+
+	for i := 0; i < 9; i++ {
+		if board[i] == EMPTY {
+			board[i] = AI_O // Make the move
+			moveVal := minimax(board, 0, false)
+			board[i] = EMPTY // Undo the move
+
+			if moveVal > bestVal {
+				bestMove = i
+				bestVal = moveVal
+			}
+		}
+	}
+	return bestMove
+}
+
+// minimax is the core recursive function
+func minimax(board []string, depth int, isMaximizing bool) int {
+	// Check for terminal states
+	if minimaxWinChecker(board, AI_O) {
+		return 10 - depth
+	}
+	if minimaxWinChecker(board, PLAYER_X) {
+		return depth - 10
+	}
+	if IsBoardFull(board) {
+		return 0
+	}
+
+	if isMaximizing {
+		// AI's turn (maximize score)
+		best := -int(math.Inf(1))
+		for i := 0; i < 9; i++ {
+			if board[i] == EMPTY {
+				board[i] = AI_O
+				best = max(best, minimax(board, depth+1, false))
+				board[i] = EMPTY
+			}
+		}
+		return best
+	}
+
+	// Player's turn (minimize score)
+	best := int(math.Inf(1))
+	for i := 0; i < 9; i++ {
+		if board[i] == EMPTY {
+			board[i] = PLAYER_X
+			best = min(best, minimax(board, depth+1, true))
+			board[i] = EMPTY
+		}
+	}
+	return best
+}
+
+// Helper functions for minimax
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
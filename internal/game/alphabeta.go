@@ -0,0 +1,354 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// infinity is used as +/- bound for alpha-beta; kept well clear of any
+// real evaluation or win score so comparisons never overflow.
+const infinity = 1 << 30
+
+// winScore is the value of an outright win, reduced by ply so the search
+// prefers a faster win (and a slower loss) over an equally "won" line.
+const winScore = 1_000_000
+
+// defaultThinkTime is used when a request doesn't set MaxThinkMS.
+const defaultThinkTime = 500 * time.Millisecond
+
+type ttBound int
+
+const (
+	ttExact ttBound = iota
+	ttLower
+	ttUpper
+)
+
+type ttEntry struct {
+	depth int
+	value int
+	bound ttBound
+}
+
+// alphaBetaSearch holds the per-call mutable search state (transposition
+// table and killer moves) so concurrent searches never share state.
+type alphaBetaSearch struct {
+	n        int
+	deadline time.Time
+	timedOut bool
+	tt       map[uint64]ttEntry
+	killers  map[int][2]int // ply -> up to two killer move indices
+}
+
+// FindBestMoveAlphaBeta does iterative deepening alpha-beta search,
+// bounded by deadline, and returns the best move found from the deepest
+// fully completed iteration.
+func FindBestMoveAlphaBeta(board []string, n int, deadline time.Time) int {
+	spots := GetEmptySpots(board)
+	if len(spots) == 0 {
+		return -1
+	}
+
+	s := &alphaBetaSearch{
+		n:        n,
+		deadline: deadline,
+		tt:       make(map[uint64]ttEntry),
+		killers:  make(map[int][2]int),
+	}
+
+	bestMove := preferredFirstMove(board, n, spots)
+	for depth := 1; depth <= len(spots); depth++ {
+		move, ok := s.rootSearch(board, depth, bestMove)
+		if !ok {
+			break // ran out of time mid-iteration; keep the previous depth's answer
+		}
+		bestMove = move
+	}
+	return bestMove
+}
+
+func preferredFirstMove(board []string, n int, spots []int) int {
+	center := (n * n) / 2
+	if board[center] == EMPTY {
+		return center
+	}
+	return spots[0]
+}
+
+func (s *alphaBetaSearch) rootSearch(board []string, depth, preferred int) (int, bool) {
+	alpha, beta := -infinity, infinity
+	bestMove := -1
+	bestValue := -infinity
+
+	for _, move := range s.orderedMoves(board, 0, preferred) {
+		if s.outOfTime() {
+			return 0, false
+		}
+
+		board[move] = AI_O
+		value := s.search(board, depth-1, 1, alpha, beta, false)
+		board[move] = EMPTY
+		if s.timedOut {
+			return 0, false
+		}
+
+		if value > bestValue {
+			bestValue = value
+			bestMove = move
+		}
+		if bestValue > alpha {
+			alpha = bestValue
+		}
+	}
+
+	if bestMove == -1 {
+		return 0, false
+	}
+	return bestMove, true
+}
+
+// search is a classic (non-negamax) alpha-beta minimax: positive scores
+// favor AI_O, negative favor PLAYER_X.
+func (s *alphaBetaSearch) search(board []string, depth, ply, alpha, beta int, maximizing bool) int {
+	if s.outOfTime() {
+		s.timedOut = true
+		return 0
+	}
+
+	if CheckWinner(board, AI_O, s.n) {
+		return winScore - ply
+	}
+	if CheckWinner(board, PLAYER_X, s.n) {
+		return -winScore + ply
+	}
+	if IsBoardFull(board) {
+		return 0
+	}
+	if depth == 0 {
+		return staticEval(board, s.n)
+	}
+
+	hash := zobristHash(board)
+	origAlpha, origBeta := alpha, beta
+	if entry, ok := s.tt[hash]; ok && entry.depth >= depth {
+		switch entry.bound {
+		case ttExact:
+			return entry.value
+		case ttLower:
+			if entry.value > alpha {
+				alpha = entry.value
+			}
+		case ttUpper:
+			if entry.value < beta {
+				beta = entry.value
+			}
+		}
+		if alpha >= beta {
+			return entry.value
+		}
+	}
+
+	var best int
+	if maximizing {
+		best = -infinity
+		for _, move := range s.orderedMoves(board, ply, -1) {
+			board[move] = AI_O
+			value := s.search(board, depth-1, ply+1, alpha, beta, false)
+			board[move] = EMPTY
+			if s.timedOut {
+				return 0
+			}
+			if value > best {
+				best = value
+			}
+			if best > alpha {
+				alpha = best
+			}
+			if alpha >= beta {
+				s.recordKiller(ply, move)
+				break
+			}
+		}
+	} else {
+		best = infinity
+		for _, move := range s.orderedMoves(board, ply, -1) {
+			board[move] = PLAYER_X
+			value := s.search(board, depth-1, ply+1, alpha, beta, true)
+			board[move] = EMPTY
+			if s.timedOut {
+				return 0
+			}
+			if value < best {
+				best = value
+			}
+			if best < beta {
+				beta = best
+			}
+			if alpha >= beta {
+				s.recordKiller(ply, move)
+				break
+			}
+		}
+	}
+
+	var bound ttBound
+	switch {
+	case best <= origAlpha:
+		bound = ttUpper
+	case best >= origBeta:
+		bound = ttLower
+	default:
+		bound = ttExact
+	}
+	s.tt[hash] = ttEntry{depth: depth, value: best, bound: bound}
+
+	return best
+}
+
+func (s *alphaBetaSearch) outOfTime() bool {
+	return time.Now().After(s.deadline)
+}
+
+// orderedMoves ranks empty cells so alpha-beta prunes as much as
+// possible: a caller-preferred move first (the previous iteration's best
+// move at the root), then this ply's killer moves, then the center, then
+// everything else in board order.
+func (s *alphaBetaSearch) orderedMoves(board []string, ply, preferred int) []int {
+	spots := GetEmptySpots(board)
+	center := (s.n * s.n) / 2
+	killers, ok := s.killers[ply]
+	if !ok {
+		killers = [2]int{-1, -1}
+	}
+
+	rank := func(m int) int {
+		switch {
+		case m == preferred:
+			return 0
+		case m == killers[0] || m == killers[1]:
+			return 1
+		case m == center:
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	sort.SliceStable(spots, func(i, j int) bool { return rank(spots[i]) < rank(spots[j]) })
+	return spots
+}
+
+func (s *alphaBetaSearch) recordKiller(ply, move int) {
+	k, ok := s.killers[ply]
+	if !ok {
+		k = [2]int{-1, -1}
+	}
+	if k[0] == move || k[1] == move {
+		return
+	}
+	k[1] = k[0]
+	k[0] = move
+	s.killers[ply] = k
+}
+
+// staticEval scores partial lines: for each row/column/diagonal, +10^k if
+// it holds k of AI_O's marks and none of PLAYER_X's, and the symmetric
+// negative for PLAYER_X.
+func staticEval(board []string, n int) int {
+	score := 0
+	for _, line := range allLines(n) {
+		countX, countO := 0, 0
+		for _, idx := range line {
+			switch board[idx] {
+			case PLAYER_X:
+				countX++
+			case AI_O:
+				countO++
+			}
+		}
+		switch {
+		case countX > 0 && countO > 0:
+			continue
+		case countO > 0:
+			score += pow10(countO)
+		case countX > 0:
+			score -= pow10(countX)
+		}
+	}
+	return score
+}
+
+func pow10(k int) int {
+	v := 1
+	for i := 0; i < k; i++ {
+		v *= 10
+	}
+	return v
+}
+
+func allLines(n int) [][]int {
+	lines := make([][]int, 0, 2*n+2)
+
+	for r := 0; r < n; r++ {
+		line := make([]int, n)
+		for c := 0; c < n; c++ {
+			line[c] = r*n + c
+		}
+		lines = append(lines, line)
+	}
+	for c := 0; c < n; c++ {
+		line := make([]int, n)
+		for r := 0; r < n; r++ {
+			line[r] = r*n + c
+		}
+		lines = append(lines, line)
+	}
+
+	diag1 := make([]int, n)
+	diag2 := make([]int, n)
+	for i := 0; i < n; i++ {
+		diag1[i] = i*n + i
+		diag2[i] = i*n + (n - 1 - i)
+	}
+	return append(lines, diag1, diag2)
+}
+
+// zobristKeys holds two random 64-bit values per cell (one per piece),
+// precomputed at startup and XORed in as cells are filled.
+var zobristKeys = generateZobristKeys(zobristMaxCells)
+
+// zobristMaxCells supports boards up to 7x7, comfortably beyond the
+// 4x4/5x5 boards this search targets.
+const zobristMaxCells = 49
+
+func generateZobristKeys(cells int) [][2]uint64 {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	keys := make([][2]uint64, cells)
+	for i := range keys {
+		keys[i][0] = r.Uint64()
+		keys[i][1] = r.Uint64()
+	}
+	return keys
+}
+
+func zobristPieceIndex(p string) int {
+	if p == PLAYER_X {
+		return 0
+	}
+	return 1
+}
+
+func zobristHash(board []string) uint64 {
+	var h uint64
+	for i, cell := range board {
+		if cell == EMPTY {
+			continue
+		}
+		if i >= len(zobristKeys) {
+			continue
+		}
+		h ^= zobristKeys[i][zobristPieceIndex(cell)]
+	}
+	return h
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// farFuture gives the search plenty of time to fully solve these small,
+// mostly-filled boards.
+func farFuture() time.Time {
+	return time.Now().Add(2 * time.Second)
+}
+
+// pastDeadline is already expired, so the search must bail out after its
+// very first time check and fall back to its precomputed first move.
+func pastDeadline() time.Time {
+	return time.Now().Add(-1 * time.Second)
+}
+
+func TestFindBestMoveAlphaBetaBlocksOpponentWin(t *testing.T) {
+	// 4x4 board with a single threat: X wins row 0 by taking index 3.
+	// Index 15 is the only other empty cell, so ignoring the block lets
+	// X win on the very next move.
+	board := []string{
+		PLAYER_X, PLAYER_X, PLAYER_X, EMPTY,
+		AI_O, AI_O, PLAYER_X, AI_O,
+		PLAYER_X, PLAYER_X, AI_O, PLAYER_X,
+		AI_O, AI_O, PLAYER_X, EMPTY,
+	}
+
+	move := FindBestMoveAlphaBeta(board, 4, farFuture())
+	if move != 3 {
+		t.Fatalf("expected AI to block the row-0 win at index 3, got %d", move)
+	}
+}
+
+func TestFindBestMoveAlphaBetaTakesOwnWin(t *testing.T) {
+	// 4x4 board where O completes row 0 by taking index 3; index 15 is
+	// a non-winning alternative that a good search must not prefer.
+	board := []string{
+		AI_O, AI_O, AI_O, EMPTY,
+		PLAYER_X, PLAYER_X, AI_O, PLAYER_X,
+		AI_O, PLAYER_X, PLAYER_X, AI_O,
+		PLAYER_X, AI_O, AI_O, EMPTY,
+	}
+
+	move := FindBestMoveAlphaBeta(board, 4, farFuture())
+	if move != 3 {
+		t.Fatalf("expected AI to take the immediate row-0 win at index 3, got %d", move)
+	}
+}
+
+func TestFindBestMoveAlphaBetaReturnsLegalMoveUnderTightDeadline(t *testing.T) {
+	board := make([]string, 16)
+	board[0] = PLAYER_X
+	board[5] = AI_O
+
+	move := FindBestMoveAlphaBeta(board, 4, pastDeadline())
+	if move < 0 || move >= len(board) {
+		t.Fatalf("expected a move within the board, got %d", move)
+	}
+	if board[move] != EMPTY {
+		t.Fatalf("expected move %d to be an empty cell, board has %q", move, board[move])
+	}
+}
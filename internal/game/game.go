@@ -0,0 +1,121 @@
+// Package game holds the board rules and AI shared by every transport
+// (the stateless /play handler, persistent sessions, the websocket feed,
+// and the arena adapter).
+package game
+
+// GameState represents the state of the game, now with variable board size.
+type GameState struct {
+	Board     []string `json:"board"`
+	BoardSize int      `json:"boardSize"`
+	Winner    string   `json:"winner"`
+
+	// MaxThinkMS bounds how long the AI may search on boards bigger than
+	// 3x3 before it must return its best move so far. Zero means use
+	// defaultThinkTime.
+	MaxThinkMS int `json:"maxThinkMs,omitempty"`
+
+	// AIMode selects which AI picks the O move: "" (the default) uses
+	// the minimax/alpha-beta search below; "learned" prefers Learned's
+	// self-play-trained weight table, falling back to search for states
+	// it hasn't seen yet.
+	AIMode string `json:"aiMode,omitempty"`
+
+	// Learned backs AIMode == "learned"; nil otherwise. Never
+	// serialized, since it is shared server-side state, not board state.
+	Learned *LearnedTable `json:"-"`
+}
+
+// AIModeLearned selects the reinforcement-learned table in AIMode.
+const AIModeLearned = "learned"
+
+// Player and AI constants
+const (
+	PLAYER_X = "X"
+	AI_O     = "O"
+	EMPTY    = ""
+)
+
+// MinBoardSize and MaxBoardSize bound every board size accepted from a
+// client, so a request can't make the server allocate or scan an
+// arbitrarily large board.
+const (
+	MinBoardSize = 3
+	MaxBoardSize = 10
+)
+
+// --- Dynamic N x N Win Checker ---
+func CheckWinner(board []string, player string, n int) bool {
+	// Check rows
+	for r := 0; r < n; r++ {
+		match := true
+		for c := 0; c < n; c++ {
+			if board[r*n+c] != player {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+
+	// Check columns
+	for c := 0; c < n; c++ {
+		match := true
+		for r := 0; r < n; r++ {
+			if board[r*n+c] != player {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+
+	// Check diagonal (top-left to bottom-right)
+	matchDiag1 := true
+	for i := 0; i < n; i++ {
+		if board[i*n+i] != player {
+			matchDiag1 = false
+			break
+		}
+	}
+	if matchDiag1 {
+		return true
+	}
+
+	// Check diagonal (top-right to bottom-left)
+	matchDiag2 := true
+	for i := 0; i < n; i++ {
+		if board[i*n+(n-1-i)] != player {
+			matchDiag2 = false
+			break
+		}
+	}
+	if matchDiag2 {
+		return true
+	}
+
+	return false
+}
+
+// IsBoardFull checks if there are any empty cells left.
+func IsBoardFull(board []string) bool {
+	for _, cell := range board {
+		if cell == EMPTY {
+			return false
+		}
+	}
+	return true
+}
+
+func GetEmptySpots(board []string) []int {
+	var emptySpots []int
+	for i, cell := range board {
+		if cell == EMPTY {
+			emptySpots = append(emptySpots, i)
+		}
+	}
+	return emptySpots
+}
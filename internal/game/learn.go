@@ -0,0 +1,316 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+)
+
+// learnedEntry is one candidate move for a canonical state, weighted by
+// how often it has paid off in self-play so far.
+type learnedEntry struct {
+	Move   int `json:"move"`
+	Weight int `json:"weight"`
+}
+
+// initialWeight is the starting weight given to every legal move the
+// first time a state is seen, following the Hexapawn educable robot:
+// enough beads to try everything, few enough to learn fast.
+const initialWeight = 3
+
+// traceStep records that a mover played Move (a canonical board index)
+// from the canonical state Key, so the outcome of the game can later be
+// back-propagated into the table.
+type traceStep struct {
+	Key  string
+	Move int
+}
+
+// LearnedTable is a reinforcement-learned policy over canonicalized board
+// states, usable on boards too large for exhaustive minimax.
+type LearnedTable struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string][]learnedEntry
+	games   int
+	wins    int // games that ended decisively (not a draw)
+}
+
+// NewLearnedTable returns an empty table that saves to path.
+func NewLearnedTable(path string) *LearnedTable {
+	return &LearnedTable{path: path, entries: make(map[string][]learnedEntry)}
+}
+
+// LoadLearnedTable reads a table previously written by Save, or returns a
+// fresh one if path does not exist yet.
+func LoadLearnedTable(path string) (*LearnedTable, error) {
+	t := NewLearnedTable(path)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored struct {
+		Entries map[string][]learnedEntry `json:"entries"`
+		Games   int                       `json:"games"`
+		Wins    int                       `json:"wins"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	if stored.Entries != nil {
+		t.entries = stored.Entries
+	}
+	t.games = stored.Games
+	t.wins = stored.Wins
+	return t, nil
+}
+
+// Save persists the table to disk as JSON.
+func (t *LearnedTable) Save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	data, err := json.Marshal(struct {
+		Entries map[string][]learnedEntry `json:"entries"`
+		Games   int                       `json:"games"`
+		Wins    int                       `json:"wins"`
+	}{Entries: t.entries, Games: t.games, Wins: t.wins})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, data, 0o644)
+}
+
+// Stats summarizes the table for GET /ai/stats.
+type Stats struct {
+	Size    int     `json:"size"`
+	Games   int     `json:"games"`
+	WinRate float64 `json:"winRate"`
+}
+
+func (t *LearnedTable) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := Stats{Size: len(t.entries), Games: t.games}
+	if t.games > 0 {
+		s.WinRate = float64(t.wins) / float64(t.games)
+	}
+	return s
+}
+
+// dihedralTransforms are the 8 rotations/reflections of a square board,
+// used to canonicalize states so symmetric positions share learning.
+var dihedralTransforms = []func(r, c, n int) (int, int){
+	func(r, c, n int) (int, int) { return r, c },
+	func(r, c, n int) (int, int) { return c, n - 1 - r },
+	func(r, c, n int) (int, int) { return n - 1 - r, n - 1 - c },
+	func(r, c, n int) (int, int) { return n - 1 - c, r },
+	func(r, c, n int) (int, int) { return r, n - 1 - c },
+	func(r, c, n int) (int, int) { return n - 1 - c, n - 1 - r },
+	func(r, c, n int) (int, int) { return n - 1 - r, c },
+	func(r, c, n int) (int, int) { return c, r },
+}
+
+// canonicalize returns the lexicographically smallest of the 8
+// rotations/reflections of board, and toOriginal, a mapping from an
+// index in that canonical board back to the matching index in board.
+func canonicalize(board []string, n int) (canon string, toOriginal []int) {
+	for _, t := range dihedralTransforms {
+		transformed := make([]string, n*n)
+		mapping := make([]int, n*n)
+		for r := 0; r < n; r++ {
+			for c := 0; c < n; c++ {
+				nr, nc := t(r, c, n)
+				transformed[nr*n+nc] = board[r*n+c]
+				mapping[nr*n+nc] = r*n + c
+			}
+		}
+		s := strings.Join(transformed, ",")
+		if canon == "" || s < canon {
+			canon = s
+			toOriginal = mapping
+		}
+	}
+	return canon, toOriginal
+}
+
+// chooseMove samples a move for player on board from the learned table,
+// initializing a fresh entry (one per empty cell, all at initialWeight)
+// the first time this exact state is seen. It returns the move in
+// board's own coordinates plus the (key, canonical move) pair to record
+// in the trace for later learning.
+func (t *LearnedTable) chooseMove(board []string, n int, player string) (move int, key string, canonMove int, ok bool) {
+	canon, toOriginal := canonicalize(board, n)
+	stateKey := canon + "|" + player
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries, exists := t.entries[stateKey]
+	if !exists {
+		for i, cell := range strings.Split(canon, ",") {
+			if cell == EMPTY {
+				entries = append(entries, learnedEntry{Move: i, Weight: initialWeight})
+			}
+		}
+		t.entries[stateKey] = entries
+	}
+
+	if len(entries) == 0 {
+		return -1, stateKey, -1, false
+	}
+
+	total := 0
+	for _, e := range entries {
+		total += e.Weight
+	}
+	pick := rand.Intn(total)
+	for _, e := range entries {
+		if pick < e.Weight {
+			return toOriginal[e.Move], stateKey, e.Move, true
+		}
+		pick -= e.Weight
+	}
+	// Unreachable in practice; fall back to the last entry.
+	last := entries[len(entries)-1]
+	return toOriginal[last.Move], stateKey, last.Move, true
+}
+
+// Move picks a move for player on board using the learned table, without
+// recording a trace step. It's used by real gameplay (via ChooseAIMove),
+// which has no eventual Learn call to feed, unlike SelfPlayGame.
+func (t *LearnedTable) Move(board []string, n int, player string) int {
+	move, _, _, ok := t.chooseMove(board, n, player)
+	if !ok {
+		return -1
+	}
+	return move
+}
+
+// FindBestMoveLearned picks a move for player on board using the learned
+// table, recording the (state, move) pair in trace so the caller can feed
+// the eventual game result back into Learn.
+func (t *LearnedTable) FindBestMoveLearned(board []string, n int, player string, trace *[]traceStep) int {
+	move, key, canonMove, ok := t.chooseMove(board, n, player)
+	if !ok {
+		return -1
+	}
+	*trace = append(*trace, traceStep{Key: key, Move: canonMove})
+	return move
+}
+
+// adjustWeight applies delta to move's weight within entries, removing it
+// if the weight drops to zero or below. It reports whether entries is now
+// empty, which triggers back-propagation to the move that led here.
+func adjustWeight(entries []learnedEntry, move, delta int) ([]learnedEntry, bool) {
+	for i, e := range entries {
+		if e.Move != move {
+			continue
+		}
+		e.Weight += delta
+		if e.Weight <= 0 {
+			entries = append(entries[:i], entries[i+1:]...)
+		} else {
+			entries[i] = e
+		}
+		break
+	}
+	return entries, len(entries) == 0
+}
+
+// Learn walks trace from the last move to the first, rewarding it on a
+// win or punishing it on a loss. If punishing a move empties its state's
+// entries entirely, the move that led into that now-hopeless state is
+// punished too, back-propagating until the trace runs out.
+func (t *LearnedTable) Learn(trace []traceStep, result string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if result != "win" && result != "loss" {
+		return
+	}
+
+	delta := 1
+	if result == "loss" {
+		delta = -1
+	}
+
+	forcePunish := false
+	for i := len(trace) - 1; i >= 0; i-- {
+		d := delta
+		if forcePunish {
+			d = -1
+		}
+
+		entries, empty := adjustWeight(t.entries[trace[i].Key], trace[i].Move, d)
+		if empty {
+			delete(t.entries, trace[i].Key)
+		} else {
+			t.entries[trace[i].Key] = entries
+		}
+		forcePunish = empty
+	}
+}
+
+// SelfPlayGame plays one game of the learned policy against itself on an
+// n x n board, then feeds the result back into the table.
+func (t *LearnedTable) SelfPlayGame(n int) string {
+	board := make([]string, n*n)
+	traces := map[string][]traceStep{PLAYER_X: nil, AI_O: nil}
+
+	turn := PLAYER_X
+	winner := "draw"
+	for {
+		trace := traces[turn]
+		move := t.FindBestMoveLearned(board, n, turn, &trace)
+		traces[turn] = trace
+		if move == -1 {
+			break
+		}
+		board[move] = turn
+
+		if CheckWinner(board, turn, n) {
+			winner = turn
+			break
+		}
+		if IsBoardFull(board) {
+			break
+		}
+		turn = opponent(turn)
+	}
+
+	for _, player := range []string{PLAYER_X, AI_O} {
+		switch winner {
+		case player:
+			t.Learn(traces[player], "win")
+		case "draw":
+			t.Learn(traces[player], "draw")
+		default:
+			t.Learn(traces[player], "loss")
+		}
+	}
+
+	t.mu.Lock()
+	t.games++
+	if winner != "draw" {
+		t.wins++
+	}
+	t.mu.Unlock()
+
+	return winner
+}
+
+func opponent(player string) string {
+	if player == PLAYER_X {
+		return AI_O
+	}
+	return PLAYER_X
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalizeAsymmetricBoard(t *testing.T) {
+	// X in the top-left corner, O in the bottom-right: asymmetric enough
+	// that only one of the 8 dihedral transforms sorts first.
+	board := []string{
+		PLAYER_X, EMPTY, EMPTY,
+		EMPTY, EMPTY, EMPTY,
+		EMPTY, EMPTY, AI_O,
+	}
+
+	canon, toOriginal := canonicalize(board, 3)
+
+	wantCanon := ",,O,,,,X,,"
+	if canon != wantCanon {
+		t.Fatalf("canon = %q, want %q", canon, wantCanon)
+	}
+
+	wantToOriginal := []int{2, 5, 8, 1, 4, 7, 0, 3, 6}
+	if !reflect.DeepEqual(toOriginal, wantToOriginal) {
+		t.Fatalf("toOriginal = %v, want %v", toOriginal, wantToOriginal)
+	}
+
+	// toOriginal must be a genuine mapping back to board: reading board
+	// through it has to reproduce canon exactly.
+	canonCells := make([]string, len(toOriginal))
+	for i, orig := range toOriginal {
+		canonCells[i] = board[orig]
+	}
+	if got := strings.Join(canonCells, ","); got != wantCanon {
+		t.Fatalf("board read through toOriginal = %q, want %q", got, wantCanon)
+	}
+}
+
+func TestLearnBackPropagatesThroughEmptiedStates(t *testing.T) {
+	tbl := NewLearnedTable("")
+	tbl.entries = map[string][]learnedEntry{
+		"stateA": {{Move: 0, Weight: 5}, {Move: 7, Weight: 2}},
+		"stateB": {{Move: 1, Weight: 1}},
+		"stateC": {{Move: 2, Weight: 1}},
+	}
+
+	trace := []traceStep{
+		{Key: "stateA", Move: 0},
+		{Key: "stateB", Move: 1},
+		{Key: "stateC", Move: 2},
+	}
+
+	// A loss punishes stateC's only move to zero, which deletes stateC
+	// and forces a -1 punishment on the move that led there (stateB's),
+	// which in turn empties and deletes stateB too, forcing -1 onto
+	// stateA's move 0 as well — even though stateA's own weight (5) was
+	// nowhere near zero.
+	tbl.Learn(trace, "loss")
+
+	if _, ok := tbl.entries["stateC"]; ok {
+		t.Fatalf("expected stateC to be deleted once its last move's weight hit zero")
+	}
+	if _, ok := tbl.entries["stateB"]; ok {
+		t.Fatalf("expected stateB to be deleted by the forced back-propagated punishment")
+	}
+
+	gotA, ok := tbl.entries["stateA"]
+	if !ok {
+		t.Fatalf("expected stateA to survive, its move 7 still has weight")
+	}
+	want := []learnedEntry{{Move: 0, Weight: 4}, {Move: 7, Weight: 2}}
+	if !reflect.DeepEqual(gotA, want) {
+		t.Fatalf("stateA entries = %v, want %v", gotA, want)
+	}
+}
+
+func TestLearnIgnoresDrawResult(t *testing.T) {
+	tbl := NewLearnedTable("")
+	tbl.entries = map[string][]learnedEntry{
+		"stateA": {{Move: 0, Weight: 3}},
+	}
+	trace := []traceStep{{Key: "stateA", Move: 0}}
+
+	tbl.Learn(trace, "draw")
+
+	want := []learnedEntry{{Move: 0, Weight: 3}}
+	if !reflect.DeepEqual(tbl.entries["stateA"], want) {
+		t.Fatalf("expected a draw to leave weights untouched, got %v", tbl.entries["stateA"])
+	}
+}
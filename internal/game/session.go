@@ -0,0 +1,297 @@
+package game
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Move is a single recorded move in a Session's history, numbered
+// monotonically from 1 so a client can fetch or replay any past turn.
+type Move struct {
+	Number int       `json:"number"`
+	Player string    `json:"player"`
+	Index  int       `json:"index"`
+	At     time.Time `json:"at"`
+}
+
+var (
+	ErrGameOver    = errors.New("game is already over")
+	ErrNotYourTurn = errors.New("not this player's turn")
+	ErrOutOfOrder  = errors.New("move number is not the next expected move")
+	ErrCellTaken   = errors.New("cell is not empty")
+	ErrNotReady    = errors.New("waiting for the second player to join")
+)
+
+// Session is a persistent, resumable game. Unlike the stateless /play
+// request, a Session is identified by an id so a client can reconnect
+// and keep playing without re-POSTing the whole board every turn.
+type Session struct {
+	mu        sync.Mutex
+	ID        string    `json:"id"`
+	Board     []string  `json:"board"`
+	BoardSize int       `json:"boardSize"`
+	Turn      string    `json:"turn"`
+	Winner    string    `json:"winner"`
+	Moves     []Move    `json:"moves"`
+	PlayerX   string    `json:"playerX"`
+	PlayerO   string    `json:"playerO"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// VsAI is false for PvP sessions created by the lobby, so SubmitMove
+	// knows not to ask the AI to reply after the X move.
+	VsAI bool `json:"vsAI"`
+
+	// Ready is false for a lobby session until its second player has
+	// joined, so SubmitMove can refuse moves made while still waiting
+	// for an opponent.
+	Ready bool `json:"ready"`
+
+	// AIMode and Learned select the AI that plays O for VsAI sessions;
+	// see GameState.AIMode. Learned is nil unless AIMode is
+	// AIModeLearned.
+	AIMode  string        `json:"aiMode,omitempty"`
+	Learned *LearnedTable `json:"-"`
+
+	// PlayerXToken/PlayerOToken authenticate a websocket connection as a
+	// given player; they are never serialized back out with the rest of
+	// the Session.
+	PlayerXToken string `json:"-"`
+	PlayerOToken string `json:"-"`
+}
+
+// SessionView is a JSON-safe snapshot of a Session, taken under its lock.
+type SessionView struct {
+	ID        string    `json:"id"`
+	Board     []string  `json:"board"`
+	BoardSize int       `json:"boardSize"`
+	Turn      string    `json:"turn"`
+	Winner    string    `json:"winner"`
+	Moves     []Move    `json:"moves"`
+	PlayerX   string    `json:"playerX"`
+	PlayerO   string    `json:"playerO"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Ready     bool      `json:"ready"`
+}
+
+// View returns a JSON-safe snapshot of the session.
+func (s *Session) View() SessionView {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return SessionView{
+		ID:        s.ID,
+		Board:     append([]string(nil), s.Board...),
+		BoardSize: s.BoardSize,
+		Turn:      s.Turn,
+		Winner:    s.Winner,
+		Moves:     append([]Move(nil), s.Moves...),
+		PlayerX:   s.PlayerX,
+		PlayerO:   s.PlayerO,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+		Ready:     s.Ready,
+	}
+}
+
+// Status is the small subset of the session a client polls most often.
+type Status struct {
+	Turn   string `json:"turn"`
+	Winner string `json:"winner"`
+}
+
+// Status returns the current turn and winner.
+func (s *Session) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Status{Turn: s.Turn, Winner: s.Winner}
+}
+
+// TokenFor returns the token that authenticates the given player color
+// for this session, or "" if the color is unrecognized.
+func (s *Session) TokenFor(player string) string {
+	switch player {
+	case s.PlayerX:
+		return s.PlayerXToken
+	case s.PlayerO:
+		return s.PlayerOToken
+	default:
+		return ""
+	}
+}
+
+// MarkReady flags the session as ready for play. The lobby calls this
+// once a PvP session's second player has joined.
+func (s *Session) MarkReady() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Ready = true
+}
+
+// MoveAt returns the nth move (1-indexed) in the session's history.
+func (s *Session) MoveAt(n int) (Move, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < 1 || n > len(s.Moves) {
+		return Move{}, false
+	}
+	return s.Moves[n-1], true
+}
+
+// SubmitMove validates and applies a move by player at index, expected to
+// be move number "number" in the session's history. If the move completes
+// play for the human side and the session is playing against the AI, the
+// AI's reply is applied as well before SubmitMove returns.
+func (s *Session) SubmitMove(player string, index, number int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.Ready {
+		return ErrNotReady
+	}
+	if s.Winner != "" {
+		return ErrGameOver
+	}
+	if player != s.Turn {
+		return ErrNotYourTurn
+	}
+	if number != len(s.Moves)+1 {
+		return ErrOutOfOrder
+	}
+	if index < 0 || index >= len(s.Board) || s.Board[index] != EMPTY {
+		return ErrCellTaken
+	}
+
+	s.applyMoveLocked(player, index)
+
+	if s.VsAI && s.Winner == "" && s.Turn == AI_O {
+		before := append([]string(nil), s.Board...)
+		state := &GameState{Board: s.Board, BoardSize: s.BoardSize, AIMode: s.AIMode, Learned: s.Learned}
+		AIMove(state)
+		s.applyMoveLocked(AI_O, indexOfAIMove(before, state.Board))
+	}
+
+	return nil
+}
+
+// applyMoveLocked records a move and advances turn/winner. Callers must
+// hold s.mu.
+func (s *Session) applyMoveLocked(player string, index int) {
+	s.Board[index] = player
+	s.Moves = append(s.Moves, Move{
+		Number: len(s.Moves) + 1,
+		Player: player,
+		Index:  index,
+		At:     time.Now(),
+	})
+	s.UpdatedAt = time.Now()
+
+	if CheckWinner(s.Board, player, s.BoardSize) {
+		s.Winner = player
+		return
+	}
+	if IsBoardFull(s.Board) {
+		s.Winner = "draw"
+		return
+	}
+
+	if player == s.PlayerX {
+		s.Turn = s.PlayerO
+	} else {
+		s.Turn = s.PlayerX
+	}
+}
+
+// indexOfAIMove diffs the board AIMove produced against the board before
+// it ran, so the single new cell can be recorded as a Move.
+func indexOfAIMove(before, after []string) int {
+	for i := range after {
+		if before[i] == EMPTY && after[i] != EMPTY {
+			return i
+		}
+	}
+	return -1
+}
+
+// Store is a concurrency-safe registry of live sessions keyed by an
+// incrementing id.
+type Store struct {
+	mu       sync.RWMutex
+	sessions map[string]*Session
+	nextID   int64
+}
+
+func NewStore() *Store {
+	return &Store{sessions: make(map[string]*Session)}
+}
+
+// Create starts a new session playing the human (X) against the AI (O).
+// It is immediately ready since the AI is always present.
+func (st *Store) Create(boardSize int) *Session {
+	return st.newSession(boardSize, PLAYER_X, AI_O, true, true)
+}
+
+// CreateLearned is like Create, but has the AI play O using learned's
+// self-play-trained weight table instead of the minimax/alpha-beta
+// search.
+func (st *Store) CreateLearned(boardSize int, learned *LearnedTable) *Session {
+	sess := st.newSession(boardSize, PLAYER_X, AI_O, true, true)
+	sess.AIMode = AIModeLearned
+	sess.Learned = learned
+	return sess
+}
+
+// CreateWithPlayers starts a new session between the given player colors,
+// used by the lobby to set up PvP games with the AI bypassed. ready
+// should be false until both lobby slots have been claimed, so the
+// lobby creator can't move before an opponent exists.
+func (st *Store) CreateWithPlayers(boardSize int, playerX, playerO string, vsAI, ready bool) *Session {
+	return st.newSession(boardSize, playerX, playerO, vsAI, ready)
+}
+
+func (st *Store) newSession(boardSize int, playerX, playerO string, vsAI, ready bool) *Session {
+	id := atomic.AddInt64(&st.nextID, 1)
+	now := time.Now()
+	sess := &Session{
+		ID:           strconv.FormatInt(id, 10),
+		Board:        make([]string, boardSize*boardSize),
+		BoardSize:    boardSize,
+		Turn:         playerX,
+		PlayerX:      playerX,
+		PlayerO:      playerO,
+		VsAI:         vsAI,
+		Ready:        ready,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		PlayerXToken: generateToken(),
+		PlayerOToken: generateToken(),
+	}
+	st.mu.Lock()
+	st.sessions[sess.ID] = sess
+	st.mu.Unlock()
+	return sess
+}
+
+func (st *Store) Get(id string) (*Session, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	sess, ok := st.sessions[id]
+	return sess, ok
+}
+
+// generateToken returns a random hex string used to authenticate a
+// player's websocket connection to a session.
+func generateToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand failing is effectively unrecoverable; fall back to
+		// a fixed-but-unique-enough value rather than panic mid-request.
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
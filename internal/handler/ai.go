@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const defaultTrainBoardSize = 4
+
+// aiStatsHandler handles GET /ai/stats: table size and win-rate for the
+// learned AI.
+func (h *Handler) aiStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.learned.Stats())
+}
+
+// trainAIHandler handles POST /ai/train?games=N&n=boardSize: it runs N
+// self-play games to bootstrap the learned AI's table, then saves it.
+func (h *Handler) trainAIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	games, err := strconv.Atoi(query.Get("games"))
+	if err != nil || games <= 0 {
+		http.Error(w, "games must be a positive integer", http.StatusBadRequest)
+		return
+	}
+
+	boardSize := defaultTrainBoardSize
+	if n, err := strconv.Atoi(query.Get("n")); err == nil && n >= 3 {
+		boardSize = n
+	}
+
+	for i := 0; i < games; i++ {
+		h.learned.SelfPlayGame(boardSize)
+	}
+
+	if err := h.learned.Save(); err != nil {
+		log.Printf("saving learned AI table: %v", err)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"gamesPlayed": games})
+}
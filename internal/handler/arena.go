@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+)
+
+// arenaHandler handles /arena: it speaks whatever tournament protocol
+// h.arenaCodec implements, translating each turn into a game.GameState,
+// delegating to the existing AI, and translating the chosen move back.
+func (h *Handler) arenaHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	state, _, yourTurn, err := h.arenaCodec.Unmarshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	move := -1
+	if yourTurn &&
+		!game.IsBoardFull(state.Board) &&
+		!game.CheckWinner(state.Board, game.PLAYER_X, state.BoardSize) &&
+		!game.CheckWinner(state.Board, game.AI_O, state.BoardSize) {
+		move = game.ChooseAIMove(state)
+	}
+
+	resp, err := h.arenaCodec.Marshal(body, state.BoardSize, move)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(resp)
+}
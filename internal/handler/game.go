@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+)
+
+// createGameRequest is the optional body for POST /game. AIMode is "" for
+// the default minimax/alpha-beta search, or game.AIModeLearned to play
+// against the reinforcement-learned table instead.
+type createGameRequest struct {
+	BoardSize int    `json:"boardSize"`
+	AIMode    string `json:"aiMode"`
+}
+
+// createGameResponse tells the caller which id to use for the rest of the
+// REST resource endpoints below, which color they were assigned, and the
+// token that authenticates their /ws connection.
+type createGameResponse struct {
+	ID          string `json:"id"`
+	PlayerColor string `json:"playerColor"`
+	Token       string `json:"token"`
+}
+
+// gameHandler dispatches the /game resource family:
+//
+//	POST   /game                - create a session
+//	GET    /game/{id}           - current board
+//	GET    /game/{id}/status    - winner/turn
+//	POST   /game/{id}/move      - submit a move
+//	GET    /game/{id}/move/{n}  - fetch a historical move
+func (h *Handler) gameHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/game"), "/"), "/")
+	if len(parts) == 1 && parts[0] == "" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.createGameHandler(w, r)
+		return
+	}
+
+	id := parts[0]
+	sess, ok := h.games.Get(id)
+	if !ok {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		h.getGameHandler(w, r, sess)
+	case len(parts) == 2 && parts[1] == "status":
+		h.getGameStatusHandler(w, r, sess)
+	case len(parts) == 2 && parts[1] == "move":
+		if r.Method != http.MethodPost {
+			http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.postMoveHandler(w, r, sess)
+	case len(parts) == 3 && parts[1] == "move":
+		n, err := strconv.Atoi(parts[2])
+		if err != nil {
+			http.Error(w, "Invalid move number", http.StatusBadRequest)
+			return
+		}
+		h.getMoveHandler(w, r, sess, n)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) createGameHandler(w http.ResponseWriter, r *http.Request) {
+	req := createGameRequest{BoardSize: 3}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	boardSize, err := resolveBoardSize(req.BoardSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.BoardSize = boardSize
+
+	var sess *game.Session
+	if req.AIMode == game.AIModeLearned {
+		sess = h.games.CreateLearned(req.BoardSize, h.learned)
+	} else {
+		sess = h.games.Create(req.BoardSize)
+	}
+	view := sess.View()
+	writeJSON(w, http.StatusCreated, createGameResponse{
+		ID:          view.ID,
+		PlayerColor: view.PlayerX,
+		Token:       sess.TokenFor(view.PlayerX),
+	})
+}
+
+func (h *Handler) getGameHandler(w http.ResponseWriter, r *http.Request, sess *game.Session) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, sess.View())
+}
+
+func (h *Handler) getGameStatusHandler(w http.ResponseWriter, r *http.Request, sess *game.Session) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, sess.Status())
+}
+
+// moveRequest is the body for POST /game/{id}/move. Number must equal the
+// next expected move number so clients can't silently skip or replay
+// turns, and Token must match the one issued for Player so one player
+// can't submit moves on the other's behalf.
+type moveRequest struct {
+	Player string `json:"player"`
+	Index  int    `json:"index"`
+	Number int    `json:"number"`
+	Token  string `json:"token"`
+}
+
+func (h *Handler) postMoveHandler(w http.ResponseWriter, r *http.Request, sess *game.Session) {
+	var req moveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Token == "" || req.Token != sess.TokenFor(req.Player) {
+		http.Error(w, "Invalid player or token", http.StatusUnauthorized)
+		return
+	}
+
+	if err := sess.SubmitMove(req.Player, req.Index, req.Number); err != nil {
+		status := moveErrorStatus(err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	view := sess.View()
+	writeJSON(w, http.StatusOK, view)
+	h.hub.broadcastView(sess.ID, view)
+}
+
+func moveErrorStatus(err error) int {
+	switch err {
+	case game.ErrCellTaken:
+		return http.StatusBadRequest
+	default:
+		return http.StatusConflict
+	}
+}
+
+func (h *Handler) getMoveHandler(w http.ResponseWriter, r *http.Request, sess *game.Session, n int) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	move, ok := sess.MoveAt(n)
+	if !ok {
+		http.Error(w, "Move not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, move)
+}
@@ -0,0 +1,128 @@
+// Package handler wires the HTTP and websocket transports to the game
+// and lobby packages.
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/arena"
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/lobby"
+)
+
+// Handler holds the shared state every endpoint needs.
+type Handler struct {
+	games      *game.Store
+	lobbies    *lobby.Store
+	learned    *game.LearnedTable
+	hub        *wsHub
+	arenaCodec arena.Codec
+}
+
+// New builds a Handler backed by the given stores.
+func New(games *game.Store, lobbies *lobby.Store, learned *game.LearnedTable) *Handler {
+	return &Handler{
+		games:      games,
+		lobbies:    lobbies,
+		learned:    learned,
+		hub:        newWSHub(),
+		arenaCodec: arena.QuestionCodec{},
+	}
+}
+
+// Register attaches every endpoint to mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/play", h.playHandler)
+
+	mux.HandleFunc("/game", h.gameHandler)
+	mux.HandleFunc("/game/", h.gameHandler)
+
+	mux.HandleFunc("/ws/", h.wsHandler)
+
+	mux.HandleFunc("/lobby", h.createLobbyHandler)
+	mux.HandleFunc("/lobby/join", h.joinLobbyHandler)
+	mux.HandleFunc("/lobby/from-passphrase/", h.resolveLobbyHandler)
+
+	mux.HandleFunc("/ai/stats", h.aiStatsHandler)
+	mux.HandleFunc("/ai/train", h.trainAIHandler)
+
+	mux.HandleFunc("/arena", h.arenaHandler)
+}
+
+// playHandler handles the stateless, single-request game logic.
+func (h *Handler) playHandler(w http.ResponseWriter, r *http.Request) {
+	// --- CORS Handling ---
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != "POST" {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// --- Decode Request ---
+	var currentState game.GameState
+	if err := json.NewDecoder(r.Body).Decode(&currentState); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if currentState.BoardSize == 0 || len(currentState.Board) != (currentState.BoardSize*currentState.BoardSize) {
+		http.Error(w, "Board size and board length do not match", http.StatusBadRequest)
+		return
+	}
+
+	// --- Game Logic ---
+
+	// 1. Check if Player (X) has already won
+	if game.CheckWinner(currentState.Board, game.PLAYER_X, currentState.BoardSize) {
+		currentState.Winner = game.PLAYER_X
+	} else if game.IsBoardFull(currentState.Board) {
+		currentState.Winner = "draw"
+	} else {
+		// 2. It's AI's turn. Find the best move.
+		game.AIMove(&currentState)
+
+		// 3. Check if AI (O) won
+		if game.CheckWinner(currentState.Board, game.AI_O, currentState.BoardSize) {
+			currentState.Winner = game.AI_O
+		} else if game.IsBoardFull(currentState.Board) {
+			currentState.Winner = "draw"
+		}
+	}
+
+	// --- Encode Response ---
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&currentState); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// resolveBoardSize validates a client-supplied board size shared by the
+// /game and /lobby create paths: 0 defaults to 3, anything outside
+// game.MinBoardSize..game.MaxBoardSize is rejected so a request can't
+// make the server allocate or search an unreasonably large board.
+func resolveBoardSize(n int) (int, error) {
+	if n == 0 {
+		n = 3
+	}
+	if n < game.MinBoardSize || n > game.MaxBoardSize {
+		return 0, fmt.Errorf("boardSize must be between %d and %d", game.MinBoardSize, game.MaxBoardSize)
+	}
+	return n, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/lobby"
+)
+
+// createLobbyRequest is the optional body for POST /lobby.
+type createLobbyRequest struct {
+	BoardSize int `json:"boardSize"`
+}
+
+// lobbySlotResponse is returned to whichever player just claimed a slot
+// in a lobby, so they can immediately start polling or connect over
+// /ws with their assigned color and token.
+type lobbySlotResponse struct {
+	Passphrase  string `json:"passphrase,omitempty"`
+	GameID      string `json:"gameId"`
+	PlayerColor string `json:"playerColor"`
+	Token       string `json:"token"`
+}
+
+// createLobbyHandler handles POST /lobby: it opens a lobby, seats the
+// caller as player X, and returns the passphrase for an opponent to join
+// with.
+func (h *Handler) createLobbyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := createLobbyRequest{BoardSize: 3}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	boardSize, err := resolveBoardSize(req.BoardSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.lobbies.Create(boardSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	view := l.Session.View()
+	writeJSON(w, http.StatusCreated, lobbySlotResponse{
+		Passphrase:  l.Passphrase,
+		GameID:      view.ID,
+		PlayerColor: view.PlayerX,
+		Token:       l.Session.TokenFor(view.PlayerX),
+	})
+}
+
+// joinLobbyRequest is the body for POST /lobby/join.
+type joinLobbyRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// joinLobbyHandler handles POST /lobby/join: it places the caller as the
+// second player in the named lobby.
+func (h *Handler) joinLobbyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req joinLobbyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.lobbies.Join(req.Passphrase)
+	if err != nil {
+		switch err {
+		case lobby.ErrLobbyNotFound:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case lobby.ErrLobbyFull:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	view := l.Session.View()
+	writeJSON(w, http.StatusOK, lobbySlotResponse{
+		GameID:      view.ID,
+		PlayerColor: view.PlayerO,
+		Token:       l.Session.TokenFor(view.PlayerO),
+	})
+}
+
+// resolveLobbyHandler handles GET /lobby/from-passphrase/{p}: it resolves
+// a passphrase to the game id it was assigned.
+func (h *Handler) resolveLobbyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	passphrase := strings.Trim(strings.TrimPrefix(r.URL.Path, "/lobby/from-passphrase/"), "/")
+	if passphrase == "" {
+		http.Error(w, "Passphrase is required", http.StatusBadRequest)
+		return
+	}
+
+	gameID, err := h.lobbies.Resolve(passphrase)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"gameId": gameID})
+}
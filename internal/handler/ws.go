@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// rateLimiter is a simple token bucket, used per-connection to stop a
+// misbehaving client from flooding the AI worker with moves.
+type rateLimiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	perSec   float64
+	last     time.Time
+}
+
+func newRateLimiter(capacity, perSec float64) *rateLimiter {
+	return &rateLimiter{tokens: capacity, capacity: capacity, perSec: perSec, last: time.Now()}
+}
+
+func (rl *rateLimiter) allow() bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	rl.tokens += now.Sub(rl.last).Seconds() * rl.perSec
+	if rl.tokens > rl.capacity {
+		rl.tokens = rl.capacity
+	}
+	rl.last = now
+
+	if rl.tokens < 1 {
+		return false
+	}
+	rl.tokens--
+	return true
+}
+
+// wsConn is one live socket attached to a player color within a Session.
+type wsConn struct {
+	conn    *websocket.Conn
+	session *game.Session
+	player  string
+	limiter *rateLimiter
+	send    chan []byte
+}
+
+// wsHub tracks at most one live connection per player color per session,
+// so a reconnect with the same identity can attach to the existing
+// session instead of closing it, and a genuine duplicate gets rejected.
+type wsHub struct {
+	mu    sync.Mutex
+	conns map[string]map[string]*wsConn // sessionID -> player -> conn
+}
+
+func newWSHub() *wsHub {
+	return &wsHub{conns: make(map[string]map[string]*wsConn)}
+}
+
+func (h *wsHub) attach(sessionID, player string, c *wsConn) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conns[sessionID] == nil {
+		h.conns[sessionID] = make(map[string]*wsConn)
+	}
+	if _, exists := h.conns[sessionID][player]; exists {
+		return false
+	}
+	h.conns[sessionID][player] = c
+	return true
+}
+
+func (h *wsHub) detach(sessionID, player string, c *wsConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conns[sessionID][player] == c {
+		delete(h.conns[sessionID], player)
+	}
+}
+
+// broadcast pushes msg to every socket currently attached to sessionID.
+func (h *wsHub) broadcast(sessionID string, msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, c := range h.conns[sessionID] {
+		select {
+		case c.send <- msg:
+		default:
+			log.Printf("dropping ws message to game %s player %s: send buffer full", sessionID, c.player)
+		}
+	}
+}
+
+// broadcastView marshals a SessionView and broadcasts it, logging rather
+// than failing the caller if marshaling somehow goes wrong.
+func (h *wsHub) broadcastView(sessionID string, view game.SessionView) {
+	msg, err := json.Marshal(view)
+	if err != nil {
+		log.Printf("marshal session view for game %s: %v", sessionID, err)
+		return
+	}
+	h.broadcast(sessionID, msg)
+}
+
+// wsMoveMessage is the shape of an incoming move over the socket.
+type wsMoveMessage struct {
+	Index  int `json:"index"`
+	Number int `json:"number"`
+}
+
+// wsHandler upgrades /ws/{gameID} and streams board updates, so the AI's
+// reply (and any opponent moves) are pushed rather than polled.
+func (h *Handler) wsHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/ws/"), "/")
+	sess, ok := h.games.Get(sessionID)
+	if !ok {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+
+	player := r.URL.Query().Get("player")
+	token := r.URL.Query().Get("token")
+	if token == "" || token != sess.TokenFor(player) {
+		http.Error(w, "Invalid player or token", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("ws upgrade failed: %v", err)
+		return
+	}
+
+	c := &wsConn{
+		conn:    conn,
+		session: sess,
+		player:  player,
+		limiter: newRateLimiter(5, 5),
+		send:    make(chan []byte, 16),
+	}
+
+	if !h.hub.attach(sessionID, player, c) {
+		log.Printf("rejecting duplicate websocket for game %s player %s", sessionID, player)
+		_ = conn.WriteJSON(map[string]string{"error": "already connected from another socket"})
+		conn.Close()
+		return
+	}
+
+	go c.writePump()
+	c.readPump(h.hub)
+}
+
+// readPump reads move messages off the socket until it closes, applying
+// each one through the same Session.SubmitMove path the REST /move
+// endpoint uses, and broadcasting the resulting board to the session.
+// Every reply is queued on c.send rather than written to c.conn
+// directly, since writePump is the only goroutine allowed to write to a
+// *websocket.Conn.
+func (c *wsConn) readPump(hub *wsHub) {
+	defer func() {
+		hub.detach(c.session.ID, c.player, c)
+		close(c.send)
+		c.conn.Close()
+	}()
+
+	for {
+		var msg wsMoveMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		if !c.limiter.allow() {
+			c.sendError("rate limit exceeded")
+			continue
+		}
+
+		if err := c.session.SubmitMove(c.player, msg.Index, msg.Number); err != nil {
+			c.sendError(err.Error())
+			continue
+		}
+
+		hub.broadcastView(c.session.ID, c.session.View())
+	}
+}
+
+// sendError queues an {"error": msg} reply on c.send, dropping it rather
+// than blocking if the buffer is full.
+func (c *wsConn) sendError(msg string) {
+	payload, err := json.Marshal(map[string]string{"error": msg})
+	if err != nil {
+		log.Printf("marshal ws error reply for game %s player %s: %v", c.session.ID, c.player, err)
+		return
+	}
+	select {
+	case c.send <- payload:
+	default:
+		log.Printf("dropping ws error reply to game %s player %s: send buffer full", c.session.ID, c.player)
+	}
+}
+
+// writePump relays queued messages to the socket.
+func (c *wsConn) writePump() {
+	for msg := range c.send {
+		if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package lobby pairs up two human players behind a short passphrase,
+// then hands the resulting game.Session off to the handler package.
+package lobby
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+)
+
+const passphraseLength = 6
+
+var passphraseAlphabet = []byte("ABCDEFGHJKLMNPQRSTUVWXYZ23456789")
+
+// generatePassphrase is a var, rather than a plain function, so tests can
+// force a collision deterministically.
+var generatePassphrase = func() string {
+	b := make([]byte, passphraseLength)
+	for i := range b {
+		b[i] = passphraseAlphabet[rand.Intn(len(passphraseAlphabet))]
+	}
+	return string(b)
+}
+
+var (
+	ErrLobbyNotFound       = errors.New("lobby not found")
+	ErrLobbyFull           = errors.New("lobby already has two players")
+	ErrPassphraseExhausted = errors.New("could not allocate a unique passphrase")
+)
+
+const maxPassphraseAttempts = 10
+
+// Lobby pairs a passphrase with the Session it hosts, and tracks how many
+// of the two PvP slots have been claimed.
+type Lobby struct {
+	mu         sync.Mutex
+	Passphrase string
+	Session    *game.Session
+	joined     int
+}
+
+// Store is a concurrency-safe registry of open lobbies, keyed by
+// passphrase.
+type Store struct {
+	mu           sync.Mutex
+	games        *game.Store
+	byPassphrase map[string]*Lobby
+}
+
+func NewStore(games *game.Store) *Store {
+	return &Store{games: games, byPassphrase: make(map[string]*Lobby)}
+}
+
+// Create opens a new lobby with the caller as the first (X) player. The
+// backing Session is created right away so its id and X token can be
+// handed back, but it stays unready (and so unplayable) until a second
+// player joins.
+func (s *Store) Create(boardSize int) (*Lobby, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var passphrase string
+	for i := 0; i < maxPassphraseAttempts; i++ {
+		p := generatePassphrase()
+		if _, exists := s.byPassphrase[p]; !exists {
+			passphrase = p
+			break
+		}
+	}
+	if passphrase == "" {
+		return nil, ErrPassphraseExhausted
+	}
+
+	sess := s.games.CreateWithPlayers(boardSize, game.PLAYER_X, game.AI_O, false, false)
+	l := &Lobby{Passphrase: passphrase, Session: sess, joined: 1}
+	s.byPassphrase[passphrase] = l
+	return l, nil
+}
+
+// Join places the caller as the second (O) player in the lobby
+// identified by passphrase.
+func (s *Store) Join(passphrase string) (*Lobby, error) {
+	s.mu.Lock()
+	l, ok := s.byPassphrase[passphrase]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrLobbyNotFound
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.joined >= 2 {
+		return nil, ErrLobbyFull
+	}
+	l.joined++
+	if l.joined == 2 {
+		l.Session.MarkReady()
+	}
+	return l, nil
+}
+
+// Resolve looks up the game id a passphrase has been assigned to.
+func (s *Store) Resolve(passphrase string) (string, error) {
+	s.mu.Lock()
+	l, ok := s.byPassphrase[passphrase]
+	s.mu.Unlock()
+	if !ok {
+		return "", ErrLobbyNotFound
+	}
+	return l.Session.ID, nil
+}
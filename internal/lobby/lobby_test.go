@@ -0,0 +1,78 @@
+package lobby
+
+import (
+	"testing"
+
+	"github.com/sujalparab7/tic-tac-toe-golang/internal/game"
+)
+
+func TestCreateRetriesOnPassphraseCollision(t *testing.T) {
+	calls := []string{"DUPE01", "DUPE01", "FRESH2"}
+	next := 0
+	orig := generatePassphrase
+	generatePassphrase = func() string {
+		p := calls[next]
+		next++
+		return p
+	}
+	defer func() { generatePassphrase = orig }()
+
+	store := NewStore(game.NewStore())
+
+	first, err := store.Create(3)
+	if err != nil {
+		t.Fatalf("unexpected error on first Create: %v", err)
+	}
+	if first.Passphrase != "DUPE01" {
+		t.Fatalf("expected first lobby to take DUPE01, got %q", first.Passphrase)
+	}
+
+	second, err := store.Create(3)
+	if err != nil {
+		t.Fatalf("unexpected error on second Create: %v", err)
+	}
+	if second.Passphrase != "FRESH2" {
+		t.Fatalf("expected collision to be retried into FRESH2, got %q", second.Passphrase)
+	}
+	if next != len(calls) {
+		t.Fatalf("expected generatePassphrase to be called %d times, got %d", len(calls), next)
+	}
+}
+
+func TestJoinAfterFullIsRejected(t *testing.T) {
+	store := NewStore(game.NewStore())
+
+	l, err := store.Create(3)
+	if err != nil {
+		t.Fatalf("unexpected error creating lobby: %v", err)
+	}
+
+	if _, err := store.Join(l.Passphrase); err != nil {
+		t.Fatalf("expected second player to join, got error: %v", err)
+	}
+
+	if _, err := store.Join(l.Passphrase); err != ErrLobbyFull {
+		t.Fatalf("expected ErrLobbyFull for a third join, got %v", err)
+	}
+}
+
+func TestResolvePassphraseToGameID(t *testing.T) {
+	store := NewStore(game.NewStore())
+
+	l, err := store.Create(3)
+	if err != nil {
+		t.Fatalf("unexpected error creating lobby: %v", err)
+	}
+
+	id, err := store.Resolve(l.Passphrase)
+	if err != nil {
+		t.Fatalf("unexpected error resolving passphrase: %v", err)
+	}
+	if id != l.Session.ID {
+		t.Fatalf("expected resolved id %q to match lobby session %q", id, l.Session.ID)
+	}
+
+	if _, err := store.Resolve("NOPE00"); err != ErrLobbyNotFound {
+		t.Fatalf("expected ErrLobbyNotFound for an unknown passphrase, got %v", err)
+	}
+}